@@ -1,7 +1,8 @@
 package main // package system
 
 import "fmt" // format package
-import "strconv"
+
+import "github.com/Zommer2108/SWP-Bonus-Go/expr"
 
 // ///////////////////////////////////////////////////////////////////////
 // Qualified import.
@@ -278,229 +279,33 @@ func example12() {
 }
 
 /////////////////////////////////////////////////////////////////////////
-// Simple expression language
-
-type Exp interface {
-	pretty() string
-	eval() Val
-}
-
-// Values
-
-type Kind int
-
-const (
-	ValueInt  Kind = 0
-	ValueBool Kind = 1
-	Undefined Kind = 2
-)
-
-type Val struct {
-	flag Kind
-	valI int
-	valB bool
-}
-
-func mkInt(x int) Val {
-	return Val{flag: ValueInt, valI: x}
-}
-func mkBool(x bool) Val {
-	return Val{flag: ValueBool, valB: x}
-}
-func mkUndefined() Val {
-	return Val{flag: Undefined}
-}
-
-func showVal(v Val) string {
-	var s string
-	switch {
-	case v.flag == ValueInt:
-		s = Num(v.valI).pretty()
-	case v.flag == ValueBool:
-		s = Bool(v.valB).pretty()
-	case v.flag == Undefined:
-		s = "Undefined"
-	}
-	return s
-}
-
-// Cases
-
-type Bool bool
-type Num int
-type Mult [2]Exp
-type Plus [2]Exp
-type And [2]Exp
-type Or [2]Exp
-
-// pretty print
-
-func (x Bool) pretty() string {
-	if x {
-		return "true"
-	} else {
-		return "false"
-	}
-
-}
-
-func (x Num) pretty() string {
-	return strconv.Itoa(int(x))
-}
-
-func (e Mult) pretty() string {
-
-	var x string
-	x = "("
-	x += e[0].pretty()
-	x += "*"
-	x += e[1].pretty()
-	x += ")"
-
-	return x
-}
-
-func (e Plus) pretty() string {
-
-	var x string
-	x = "("
-	x += e[0].pretty()
-	x += "+"
-	x += e[1].pretty()
-	x += ")"
-
-	return x
-}
-
-func (e And) pretty() string {
-
-	var x string
-	x = "("
-	x += e[0].pretty()
-	x += "&&"
-	x += e[1].pretty()
-	x += ")"
-
-	return x
-}
-
-func (e Or) pretty() string {
-
-	var x string
-	x = "("
-	x += e[0].pretty()
-	x += "||"
-	x += e[1].pretty()
-	x += ")"
-
-	return x
-}
-
-// Evaluator
-
-func (x Bool) eval() Val {
-	return mkBool((bool)(x))
-}
-
-func (x Num) eval() Val {
-	return mkInt((int)(x))
-}
-
-func (e Mult) eval() Val {
-	n1 := e[0].eval()
-	n2 := e[1].eval()
-	if n1.flag == ValueInt && n2.flag == ValueInt {
-		return mkInt(n1.valI * n2.valI)
-	}
-	return mkUndefined()
-}
-
-func (e Plus) eval() Val {
-	n1 := e[0].eval()
-	n2 := e[1].eval()
-	if n1.flag == ValueInt && n2.flag == ValueInt {
-		return mkInt(n1.valI + n2.valI)
-	}
-	return mkUndefined()
-}
-
-func (e And) eval() Val {
-	b1 := e[0].eval()
-	b2 := e[1].eval()
-	switch {
-	case b1.flag == ValueBool && b1.valB == false:
-		return mkBool(false)
-	case b1.flag == ValueBool && b2.flag == ValueBool:
-		return mkBool(b1.valB && b2.valB)
-	}
-	return mkUndefined()
-}
-
-func (e Or) eval() Val {
-	b1 := e[0].eval()
-	b2 := e[1].eval()
-	switch {
-	case b1.flag == ValueBool && b1.valB == true:
-		return mkBool(true)
-	case b1.flag == ValueBool && b2.flag == ValueBool:
-		return mkBool(b1.valB || b2.valB)
-	}
-	return mkUndefined()
-}
-
-// Helper functions to build ASTs by hand
-
-func number(x int) Exp {
-	return Num(x)
-}
-
-func boolean(x bool) Exp {
-	return Bool(x)
-}
-
-func plus(x, y Exp) Exp {
-	return (Plus)([2]Exp{x, y})
-
-	// The type Plus is defined as the two element array consisting of Exp elements.
-	// Plus and [2]Exp are isomorphic but different types.
-	// We first build the AST value [2]Exp{x,y}.
-	// Then cast this value (of type [2]Exp) into a value of type Plus.
-
-}
-
-func mult(x, y Exp) Exp {
-	return (Mult)([2]Exp{x, y})
-}
-
-func and(x, y Exp) Exp {
-	return (And)([2]Exp{x, y})
-}
-
-func or(x, y Exp) Exp {
-	return (Or)([2]Exp{x, y})
-}
+// Simple expression language.
+//
+// The AST, pretty-printer and evaluator (Exp, Val, Bool, Num, Plus,
+// Mult, And, Or, ...) live in the expr package now, since the IR and
+// analysis packages built on top of them can't import package main.
 
 func example13() {
 
-	run := func(e Exp) {
+	run := func(e expr.Exp) {
 		fmt.Printf("\n ******* ")
-		fmt.Printf("\n %s", e.pretty())
-		fmt.Printf("\n %s", showVal(e.eval()))
+		fmt.Printf("\n %s", e.Pretty())
+		fmt.Printf("\n %s", expr.ShowVal(e.Eval()))
 	}
 
 	{
-		ast := plus(mult(number(1), number(2)), number(0))
+		ast := expr.MkPlus(expr.MkMult(expr.Num(1), expr.Num(2)), expr.Num(0))
 
 		run(ast)
 	}
 
 	{
-		ast := and(boolean(true), number(0))
+		ast := expr.MkAnd(expr.Bool(true), expr.Num(0))
 		run(ast)
 	}
 
 	{
-		ast := or(boolean(false), number(0))
+		ast := expr.MkOr(expr.Bool(false), expr.Num(0))
 		run(ast)
 	}
 
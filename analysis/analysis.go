@@ -0,0 +1,193 @@
+// Package analysis implements a small pluggable analysis framework over
+// expr.Exp, patterned on golang.org/x/tools/go/analysis: analyzers declare
+// what they Require, the driver runs prerequisites first and hands each
+// analyzer a Pass it can use to read its prerequisites' results and report
+// diagnostics.
+package analysis
+
+import (
+	"fmt"
+
+	"github.com/Zommer2108/SWP-Bonus-Go/expr"
+)
+
+// Analyzer is one named, independently pluggable analysis.
+type Analyzer struct {
+	Name     string
+	Doc      string
+	Requires []*Analyzer
+	Run      func(*Pass) (interface{}, error)
+}
+
+// Pass is the interface an Analyzer's Run function sees: the tree to
+// analyze, the results of its prerequisites, and a sink for diagnostics.
+type Pass struct {
+	Analyzer *Analyzer
+	Root     expr.Exp
+	ResultOf map[*Analyzer]interface{}
+
+	report func(Diagnostic)
+}
+
+// Report records a diagnostic pointing at node.
+func (p *Pass) Report(node expr.Exp, msg string) {
+	p.report(Diagnostic{
+		Analyzer: p.Analyzer.Name,
+		At:       node.Pretty(),
+		Node:     node,
+		Msg:      msg,
+	})
+}
+
+// Diagnostic is one finding reported by an Analyzer. expr.Exp carries no
+// source position of its own, so At holds the pretty-printed form of the
+// offending sub-AST in its place.
+type Diagnostic struct {
+	Analyzer string
+	At       string
+	Node     expr.Exp
+	Msg      string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s", d.Analyzer, d.At, d.Msg)
+}
+
+// Run runs each of analyzers (and, transitively, everything it
+// Requires) exactly once over root, in an order that respects Requires,
+// and returns every Diagnostic reported along the way.
+func Run(root expr.Exp, analyzers ...*Analyzer) []Diagnostic {
+	var diags []Diagnostic
+	results := make(map[*Analyzer]interface{})
+	done := make(map[*Analyzer]bool)
+
+	var run func(a *Analyzer)
+	run = func(a *Analyzer) {
+		if done[a] {
+			return
+		}
+		done[a] = true
+
+		for _, req := range a.Requires {
+			run(req)
+		}
+
+		pass := &Pass{
+			Analyzer: a,
+			Root:     root,
+			ResultOf: results,
+			report:   func(d Diagnostic) { diags = append(diags, d) },
+		}
+		res, err := a.Run(pass)
+		if err != nil {
+			diags = append(diags, Diagnostic{Analyzer: a.Name, At: root.Pretty(), Msg: err.Error()})
+			return
+		}
+		results[a] = res
+	}
+
+	for _, a := range analyzers {
+		run(a)
+	}
+	return diags
+}
+
+// children returns the immediate sub-expressions of e, or nil for a leaf.
+func children(e expr.Exp) []expr.Exp {
+	switch x := e.(type) {
+	case expr.Plus:
+		return []expr.Exp{x[0], x[1]}
+	case expr.Mult:
+		return []expr.Exp{x[0], x[1]}
+	case expr.And:
+		return []expr.Exp{x[0], x[1]}
+	case expr.Or:
+		return []expr.Exp{x[0], x[1]}
+	case expr.Let:
+		return []expr.Exp{x.Bound, x.Body}
+	default:
+		return nil
+	}
+}
+
+// walk visits e and every sub-expression of e, in pre-order.
+func walk(e expr.Exp, visit func(expr.Exp)) {
+	visit(e)
+	for _, c := range children(e) {
+		walk(c, visit)
+	}
+}
+
+// TypecheckAnalyzer surfaces the errors found by the standalone type
+// checker (see expr.Check) as diagnostics.
+var TypecheckAnalyzer = &Analyzer{
+	Name: "typecheck",
+	Doc:  "reports static type errors found by Check",
+	Run: func(pass *Pass) (interface{}, error) {
+		_, _, errs := expr.Check(pass.Root)
+		for _, e := range errs {
+			pass.Report(e.Node, e.Error())
+		}
+		return nil, nil
+	},
+}
+
+// DeadBranchAnalyzer flags operands that can never be evaluated under the
+// language's short-circuit semantics: the right-hand side of
+// false && X, and the right-hand side of true || X.
+var DeadBranchAnalyzer = &Analyzer{
+	Name: "deadbranch",
+	Doc:  "reports operands made unreachable by short-circuit evaluation",
+	Run: func(pass *Pass) (interface{}, error) {
+		walk(pass.Root, func(e expr.Exp) {
+			switch x := e.(type) {
+			case expr.And:
+				if b, ok := x[0].(expr.Bool); ok && !bool(b) {
+					pass.Report(x[1], "unreachable: left operand of && is always false")
+				}
+			case expr.Or:
+				if b, ok := x[0].(expr.Bool); ok && bool(b) {
+					pass.Report(x[1], "unreachable: left operand of || is always true")
+				}
+			}
+		})
+		return nil, nil
+	},
+}
+
+// NewGoconstAnalyzer returns an analyzer, in the spirit of
+// jgautheron/goconst, that reports any integer or boolean literal
+// repeated at least min times in the tree, suggesting it be factored
+// into a named constant.
+func NewGoconstAnalyzer(min int) *Analyzer {
+	return &Analyzer{
+		Name: "goconst",
+		Doc:  "reports literals repeated at least min times",
+		Run: func(pass *Pass) (interface{}, error) {
+			var order []string
+			nodes := make(map[string][]expr.Exp)
+
+			walk(pass.Root, func(e expr.Exp) {
+				var key string
+				switch e.(type) {
+				case expr.Num, expr.Bool:
+					key = e.Pretty()
+				default:
+					return
+				}
+				if _, ok := nodes[key]; !ok {
+					order = append(order, key)
+				}
+				nodes[key] = append(nodes[key], e)
+			})
+
+			for _, key := range order {
+				occ := nodes[key]
+				if len(occ) >= min {
+					pass.Report(occ[0], fmt.Sprintf("literal %s repeated %d times; consider factoring it into a named constant", key, len(occ)))
+				}
+			}
+			return nil, nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Zommer2108/SWP-Bonus-Go/expr"
+)
+
+func TestRunTypecheckAnalyzer(t *testing.T) {
+	root := expr.MkAnd(expr.Bool(true), expr.Num(0))
+	diags := Run(root, TypecheckAnalyzer)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	if diags[0].Analyzer != "typecheck" {
+		t.Errorf("diagnostic from wrong analyzer: %v", diags[0])
+	}
+}
+
+func TestRunDeadBranchAnalyzer(t *testing.T) {
+	root := expr.MkOr(expr.MkAnd(expr.Bool(false), expr.Num(1)), expr.Bool(true))
+	diags := Run(root, DeadBranchAnalyzer)
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+	if diags[0].At != "1" {
+		t.Errorf("expected diagnostic on the dead operand %q, got %q", "1", diags[0].At)
+	}
+}
+
+func TestRunGoconstAnalyzer(t *testing.T) {
+	root := expr.MkPlus(expr.MkMult(expr.Num(7), expr.Num(7)), expr.Num(7))
+	diags := Run(root, NewGoconstAnalyzer(3))
+	if len(diags) != 1 {
+		t.Fatalf("expected 1 diagnostic, got %d: %v", len(diags), diags)
+	}
+
+	diags = Run(root, NewGoconstAnalyzer(4))
+	if len(diags) != 0 {
+		t.Errorf("expected no diagnostics below the threshold, got %v", diags)
+	}
+}
+
+func TestRunOnlyRunsEachAnalyzerOnce(t *testing.T) {
+	calls := 0
+	base := &Analyzer{
+		Name: "base",
+		Run: func(pass *Pass) (interface{}, error) {
+			calls++
+			return 42, nil
+		},
+	}
+	dependent := &Analyzer{
+		Name:     "dependent",
+		Requires: []*Analyzer{base},
+		Run: func(pass *Pass) (interface{}, error) {
+			if pass.ResultOf[base] != 42 {
+				t.Errorf("dependent did not see base's result: %v", pass.ResultOf[base])
+			}
+			return nil, nil
+		},
+	}
+
+	Run(expr.Num(1), base, dependent)
+	if calls != 1 {
+		t.Errorf("base analyzer ran %d times, want 1", calls)
+	}
+}
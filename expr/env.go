@@ -0,0 +1,141 @@
+package expr
+
+/////////////////////////////////////////////////////////////////////////
+// Variable bindings.
+//
+// Eval() alone can't give a variable a value, so evaluation moves to an
+// environment-passing model: EvalEnv(Env) Val threads a lookup table
+// through the AST. Eval() is kept for back-compat and is now just
+// EvalEnv(nil); a Var looked up against a nil (or incomplete) Env is
+// simply Undefined, same as any other ill-formed expression.
+
+// Env maps variable names to the value currently bound to them.
+type Env map[string]Val
+
+// extend returns a copy of env with name bound to v, leaving env itself
+// untouched. Let uses this (rather than mutating env in place) so that
+// shadowing a name in a nested Let does not leak into the enclosing
+// scope once that nested Let's body has been evaluated.
+func (env Env) extend(name string, v Val) Env {
+	next := make(Env, len(env)+1)
+	for k, val := range env {
+		next[k] = val
+	}
+	next[name] = v
+	return next
+}
+
+// EvalEnv for the existing, variable-free cases: leaves ignore env, and
+// composites thread it down to their children instead of calling Eval().
+
+func (x Bool) EvalEnv(env Env) Val {
+	return x.Eval()
+}
+
+func (x Num) EvalEnv(env Env) Val {
+	return x.Eval()
+}
+
+func (e Mult) EvalEnv(env Env) Val {
+	n1 := e[0].EvalEnv(env)
+	n2 := e[1].EvalEnv(env)
+	if n1.Flag == ValueInt && n2.Flag == ValueInt {
+		return MkInt(n1.ValI * n2.ValI)
+	}
+	return MkUndefined()
+}
+
+func (e Plus) EvalEnv(env Env) Val {
+	n1 := e[0].EvalEnv(env)
+	n2 := e[1].EvalEnv(env)
+	if n1.Flag == ValueInt && n2.Flag == ValueInt {
+		return MkInt(n1.ValI + n2.ValI)
+	}
+	return MkUndefined()
+}
+
+func (e And) EvalEnv(env Env) Val {
+	b1 := e[0].EvalEnv(env)
+	b2 := e[1].EvalEnv(env)
+	switch {
+	case b1.Flag == ValueBool && b1.ValB == false:
+		return MkBool(false)
+	case b1.Flag == ValueBool && b2.Flag == ValueBool:
+		return MkBool(b1.ValB && b2.ValB)
+	}
+	return MkUndefined()
+}
+
+func (e Or) EvalEnv(env Env) Val {
+	b1 := e[0].EvalEnv(env)
+	b2 := e[1].EvalEnv(env)
+	switch {
+	case b1.Flag == ValueBool && b1.ValB == true:
+		return MkBool(true)
+	case b1.Flag == ValueBool && b2.Flag == ValueBool:
+		return MkBool(b1.ValB || b2.ValB)
+	}
+	return MkUndefined()
+}
+
+// Var
+//
+// Two Var nodes for the same name are still different occurrences in the
+// AST (e.g. one bound by an outer Let, the other by an unrelated inner
+// Let that happens to shadow the same name), but as a bare string they'd
+// be indistinguishable as map keys. occ disambiguates them: MkVar mints
+// a fresh one per call, so Check's per-node side table (keyed on Exp)
+// doesn't conflate two syntactically identical but semantically distinct
+// variable occurrences.
+
+type Var struct {
+	Name string
+	occ  int
+}
+
+var nextVarOcc int
+
+func (v Var) Pretty() string {
+	return v.Name
+}
+
+func (v Var) Eval() Val {
+	return v.EvalEnv(nil)
+}
+
+func (v Var) EvalEnv(env Env) Val {
+	if val, ok := env[v.Name]; ok {
+		return val
+	}
+	return MkUndefined()
+}
+
+// Let
+
+type Let struct {
+	Name  string
+	Bound Exp
+	Body  Exp
+}
+
+func (l Let) Pretty() string {
+	return "(let " + l.Name + " = " + l.Bound.Pretty() + " in " + l.Body.Pretty() + ")"
+}
+
+func (l Let) Eval() Val {
+	return l.EvalEnv(nil)
+}
+
+func (l Let) EvalEnv(env Env) Val {
+	bound := l.Bound.EvalEnv(env)
+	return l.Body.EvalEnv(env.extend(l.Name, bound))
+}
+
+func MkVar(name string) Exp {
+	nextVarOcc++
+	return Var{Name: name, occ: nextVarOcc}
+}
+
+func MkLet(name string, bound, body Exp) Exp {
+	return Let{Name: name, Bound: bound, Body: body}
+}
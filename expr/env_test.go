@@ -0,0 +1,69 @@
+package expr
+
+import "testing"
+
+func TestLetEval(t *testing.T) {
+	// let x = 1 + 2 in x * x
+	e := MkLet("x", MkPlus(Num(1), Num(2)), MkMult(MkVar("x"), MkVar("x")))
+	if v := e.Eval(); v != MkInt(9) {
+		t.Errorf("Eval() = %v, want 9", v)
+	}
+}
+
+func TestLetShadowing(t *testing.T) {
+	// let x = 1 in (let x = 2 in x) + x  --  inner x must not leak out
+	inner := MkLet("x", Num(2), MkVar("x"))
+	e := MkLet("x", Num(1), MkPlus(inner, MkVar("x")))
+	if v := e.Eval(); v != MkInt(3) {
+		t.Errorf("Eval() = %v, want 3 (2 + 1)", v)
+	}
+}
+
+func TestVarUseBeforeDef(t *testing.T) {
+	e := MkVar("x")
+	if v := e.Eval(); v.Flag != Undefined {
+		t.Errorf("Eval() of an unbound variable = %v, want Undefined", v)
+	}
+}
+
+func TestCheckLetAndVar(t *testing.T) {
+	e := MkLet("x", MkPlus(Num(1), Num(2)), MkMult(MkVar("x"), MkVar("x")))
+	ty, _, errs := Check(e)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected type errors: %v", errs)
+	}
+	if ty != TyInt {
+		t.Errorf("Check(%s) = %s, want Int", e.Pretty(), ty)
+	}
+}
+
+func TestCheckSideTableKeepsShadowedVarsDistinct(t *testing.T) {
+	// Two unrelated Lets that happen to reuse the name "x" for different
+	// types. Their Var("x") occurrences are syntactically identical but
+	// must not share a side-table entry, or whichever is checked second
+	// clobbers the type recorded for the other.
+	xInt := MkVar("x")
+	xBool := MkVar("x")
+	intLet := MkLet("x", Num(1), xInt)       // x : Int
+	boolLet := MkLet("x", Bool(true), xBool) // x : Bool
+
+	// Plus forces a type error on the Bool side but still checks both
+	// operands, so both Var occurrences end up in the side table.
+	_, types, errs := Check(MkPlus(intLet, boolLet))
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly 1 type error, got %d: %v", len(errs), errs)
+	}
+	if ty := types[xInt]; ty != TyInt {
+		t.Errorf("types[xInt] = %s, want Int", ty)
+	}
+	if ty := types[xBool]; ty != TyBool {
+		t.Errorf("types[xBool] = %s, want Bool (got clobbered by xInt's entry)", ty)
+	}
+}
+
+func TestCheckUnboundVariable(t *testing.T) {
+	_, _, errs := Check(MkVar("x"))
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly 1 type error, got %d: %v", len(errs), errs)
+	}
+}
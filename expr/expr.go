@@ -0,0 +1,207 @@
+// Package expr implements the simple expression language originally
+// defined inline in SWP-Go-Examples.go: its AST, pretty-printer and
+// evaluator. It lives in its own package (rather than package main) so
+// that the ir and analysis packages built on top of it can import and
+// operate on Exp without depending on package main, which Go does not
+// allow.
+package expr
+
+import "strconv"
+
+// Exp is any node in the expression AST.
+type Exp interface {
+	Pretty() string
+	Eval() Val
+	EvalEnv(Env) Val
+}
+
+// Values
+
+type Kind int
+
+const (
+	ValueInt  Kind = 0
+	ValueBool Kind = 1
+	Undefined Kind = 2
+)
+
+type Val struct {
+	Flag Kind
+	ValI int
+	ValB bool
+}
+
+func MkInt(x int) Val {
+	return Val{Flag: ValueInt, ValI: x}
+}
+func MkBool(x bool) Val {
+	return Val{Flag: ValueBool, ValB: x}
+}
+func MkUndefined() Val {
+	return Val{Flag: Undefined}
+}
+
+func ShowVal(v Val) string {
+	var s string
+	switch {
+	case v.Flag == ValueInt:
+		s = Num(v.ValI).Pretty()
+	case v.Flag == ValueBool:
+		s = Bool(v.ValB).Pretty()
+	case v.Flag == Undefined:
+		s = "Undefined"
+	}
+	return s
+}
+
+// Cases
+
+type Bool bool
+type Num int
+type Mult [2]Exp
+type Plus [2]Exp
+type And [2]Exp
+type Or [2]Exp
+
+// pretty print
+
+func (x Bool) Pretty() string {
+	if x {
+		return "true"
+	} else {
+		return "false"
+	}
+
+}
+
+func (x Num) Pretty() string {
+	return strconv.Itoa(int(x))
+}
+
+func (e Mult) Pretty() string {
+
+	var x string
+	x = "("
+	x += e[0].Pretty()
+	x += "*"
+	x += e[1].Pretty()
+	x += ")"
+
+	return x
+}
+
+func (e Plus) Pretty() string {
+
+	var x string
+	x = "("
+	x += e[0].Pretty()
+	x += "+"
+	x += e[1].Pretty()
+	x += ")"
+
+	return x
+}
+
+func (e And) Pretty() string {
+
+	var x string
+	x = "("
+	x += e[0].Pretty()
+	x += "&&"
+	x += e[1].Pretty()
+	x += ")"
+
+	return x
+}
+
+func (e Or) Pretty() string {
+
+	var x string
+	x = "("
+	x += e[0].Pretty()
+	x += "||"
+	x += e[1].Pretty()
+	x += ")"
+
+	return x
+}
+
+// Evaluator
+
+func (x Bool) Eval() Val {
+	return MkBool((bool)(x))
+}
+
+func (x Num) Eval() Val {
+	return MkInt((int)(x))
+}
+
+func (e Mult) Eval() Val {
+	n1 := e[0].Eval()
+	n2 := e[1].Eval()
+	if n1.Flag == ValueInt && n2.Flag == ValueInt {
+		return MkInt(n1.ValI * n2.ValI)
+	}
+	return MkUndefined()
+}
+
+func (e Plus) Eval() Val {
+	n1 := e[0].Eval()
+	n2 := e[1].Eval()
+	if n1.Flag == ValueInt && n2.Flag == ValueInt {
+		return MkInt(n1.ValI + n2.ValI)
+	}
+	return MkUndefined()
+}
+
+func (e And) Eval() Val {
+	b1 := e[0].Eval()
+	b2 := e[1].Eval()
+	switch {
+	case b1.Flag == ValueBool && b1.ValB == false:
+		return MkBool(false)
+	case b1.Flag == ValueBool && b2.Flag == ValueBool:
+		return MkBool(b1.ValB && b2.ValB)
+	}
+	return MkUndefined()
+}
+
+func (e Or) Eval() Val {
+	b1 := e[0].Eval()
+	b2 := e[1].Eval()
+	switch {
+	case b1.Flag == ValueBool && b1.ValB == true:
+		return MkBool(true)
+	case b1.Flag == ValueBool && b2.Flag == ValueBool:
+		return MkBool(b1.ValB || b2.ValB)
+	}
+	return MkUndefined()
+}
+
+// Helper functions to build ASTs by hand.
+//
+// Num and Bool are usable directly as Exp (e.g. expr.Num(1)); the binary
+// cases get an MkXxx constructor since their own names (Plus, Mult, And,
+// Or) are already taken by the case types themselves.
+
+func MkPlus(x, y Exp) Exp {
+	return (Plus)([2]Exp{x, y})
+
+	// The type Plus is defined as the two element array consisting of Exp elements.
+	// Plus and [2]Exp are isomorphic but different types.
+	// We first build the AST value [2]Exp{x,y}.
+	// Then cast this value (of type [2]Exp) into a value of type Plus.
+
+}
+
+func MkMult(x, y Exp) Exp {
+	return (Mult)([2]Exp{x, y})
+}
+
+func MkAnd(x, y Exp) Exp {
+	return (And)([2]Exp{x, y})
+}
+
+func MkOr(x, y Exp) Exp {
+	return (Or)([2]Exp{x, y})
+}
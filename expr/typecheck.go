@@ -0,0 +1,172 @@
+package expr
+
+import "fmt"
+
+/////////////////////////////////////////////////////////////////////////
+// Static type checking, separate from eval.
+//
+// eval already computes a Val at runtime and falls back to Undefined on a
+// mismatch (e.g. expr.MkAnd(expr.Bool(true), expr.Num(0))). Check walks
+// the AST up front and assigns each node a Type, reporting every
+// mismatch it finds instead of only discovering the first one at eval
+// time.
+
+// Type is the static type of an expression.
+type Type int
+
+const (
+	TyInt Type = iota
+	TyBool
+	TyError
+)
+
+func (t Type) String() string {
+	switch t {
+	case TyInt:
+		return "Int"
+	case TyBool:
+		return "Bool"
+	default:
+		return "<error>"
+	}
+}
+
+// TypeError reports that the sub-expression Node did not have the
+// expected type. Msg, when set, is reported verbatim instead of the
+// usual "expected X, got Y" (used for errors, like an unbound variable,
+// that aren't a mismatch between two types).
+type TypeError struct {
+	Node     Exp
+	Expected Type
+	Actual   Type
+	Msg      string
+}
+
+func (e TypeError) Error() string {
+	if e.Msg != "" {
+		return fmt.Sprintf("%s: %s", e.Node.Pretty(), e.Msg)
+	}
+	return fmt.Sprintf("%s: expected %s, got %s", e.Node.Pretty(), e.Expected, e.Actual)
+}
+
+// gamma is the typing context Γ: the static counterpart of Env, mapping
+// the names currently in scope to their type.
+type gamma map[string]Type
+
+// Check walks e and assigns every sub-expression a Type, returning the
+// type of the root, a side table recording the type inferred for every
+// sub-expression (rather than mutating e), and a TypeError for every
+// ill-typed sub-expression it finds. It does not short-circuit at the
+// first mismatch: each offending node is reported independently.
+func Check(e Exp) (Type, map[Exp]Type, []TypeError) {
+	types := make(map[Exp]Type)
+	var errs []TypeError
+	t := checkInto(e, nil, types, &errs)
+	return t, types, errs
+}
+
+// checkInto infers the type of e under Γ, recording it in types and
+// appending any TypeErrors found in e or its children to errs.
+func checkInto(e Exp, g gamma, types map[Exp]Type, errs *[]TypeError) Type {
+	var t Type
+
+	switch x := e.(type) {
+	case Num:
+		t = TyInt
+
+	case Bool:
+		t = TyBool
+
+	case Var:
+		if ty, ok := g[x.Name]; ok {
+			t = ty
+		} else {
+			*errs = append(*errs, TypeError{Node: e, Msg: "undefined variable " + x.Name})
+			t = TyError
+		}
+
+	case Let:
+		tBound := checkInto(x.Bound, g, types, errs)
+		t = checkInto(x.Body, g.extend(x.Name, tBound), types, errs)
+
+	case Plus:
+		t = checkArith(x[0], x[1], g, types, errs)
+
+	case Mult:
+		t = checkArith(x[0], x[1], g, types, errs)
+
+	case And:
+		t = checkLogic(x[0], x[1], g, types, errs)
+
+	case Or:
+		t = checkLogic(x[0], x[1], g, types, errs)
+
+	default:
+		t = TyError
+	}
+
+	types[e] = t
+	return t
+}
+
+// extend returns a copy of g with name bound to ty, leaving g itself
+// untouched, mirroring Env.extend so a Let's binding never leaks into
+// its enclosing scope.
+func (g gamma) extend(name string, ty Type) gamma {
+	next := make(gamma, len(g)+1)
+	for k, v := range g {
+		next[k] = v
+	}
+	next[name] = ty
+	return next
+}
+
+func checkArith(l, r Exp, g gamma, types map[Exp]Type, errs *[]TypeError) Type {
+	lt := checkInto(l, g, types, errs)
+	rt := checkInto(r, g, types, errs)
+
+	ok := true
+	if lt != TyInt {
+		*errs = append(*errs, TypeError{Node: l, Expected: TyInt, Actual: lt})
+		ok = false
+	}
+	if rt != TyInt {
+		*errs = append(*errs, TypeError{Node: r, Expected: TyInt, Actual: rt})
+		ok = false
+	}
+	if !ok {
+		return TyError
+	}
+	return TyInt
+}
+
+func checkLogic(l, r Exp, g gamma, types map[Exp]Type, errs *[]TypeError) Type {
+	lt := checkInto(l, g, types, errs)
+	rt := checkInto(r, g, types, errs)
+
+	ok := true
+	if lt != TyBool {
+		*errs = append(*errs, TypeError{Node: l, Expected: TyBool, Actual: lt})
+		ok = false
+	}
+	if rt != TyBool {
+		*errs = append(*errs, TypeError{Node: r, Expected: TyBool, Actual: rt})
+		ok = false
+	}
+	if !ok {
+		return TyError
+	}
+	return TyBool
+}
+
+// EvalChecked behaves like Eval, but first runs Check and refuses to
+// evaluate an ill-typed expression. It also returns Check's side table,
+// so a caller that already needs per-node static types doesn't have to
+// run Check a second time to get them.
+func EvalChecked(e Exp) (Val, map[Exp]Type, []TypeError) {
+	_, types, errs := Check(e)
+	if len(errs) > 0 {
+		return MkUndefined(), types, errs
+	}
+	return e.Eval(), types, nil
+}
@@ -0,0 +1,60 @@
+package expr
+
+import "testing"
+
+func TestCheckWellTyped(t *testing.T) {
+	e := MkPlus(MkMult(Num(1), Num(2)), Num(0))
+	ty, _, errs := Check(e)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected type errors: %v", errs)
+	}
+	if ty != TyInt {
+		t.Errorf("Check(%s) = %s, want Int", e.Pretty(), ty)
+	}
+}
+
+func TestCheckMismatch(t *testing.T) {
+	e := MkAnd(Bool(true), Num(0))
+	_, _, errs := Check(e)
+	if len(errs) != 1 {
+		t.Fatalf("expected exactly 1 type error, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestCheckReportsEveryMismatch(t *testing.T) {
+	// Both operands of the outer `and` are ill-typed Plus/Or nodes whose
+	// own operands are also ill-typed: errors should surface at every
+	// level, not just the first one found.
+	e := MkAnd(MkPlus(Bool(true), Num(1)), MkOr(Num(1), Bool(true)))
+	_, _, errs := Check(e)
+	if len(errs) < 3 {
+		t.Errorf("expected errors from multiple sub-expressions, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestCheckSideTableRecordsPerNodeTypes(t *testing.T) {
+	inner := MkPlus(Num(1), Num(2))
+	outer := MkMult(inner, Num(3))
+
+	_, types, errs := Check(outer)
+	if len(errs) != 0 {
+		t.Fatalf("unexpected type errors: %v", errs)
+	}
+	if ty := types[outer]; ty != TyInt {
+		t.Errorf("types[outer] = %s, want Int", ty)
+	}
+	if ty := types[inner]; ty != TyInt {
+		t.Errorf("types[inner] = %s, want Int", ty)
+	}
+}
+
+func TestEvalCheckedRefusesIllTyped(t *testing.T) {
+	e := MkAnd(Bool(true), Num(0))
+	v, _, errs := EvalChecked(e)
+	if len(errs) == 0 {
+		t.Fatalf("expected type errors")
+	}
+	if v.Flag != Undefined {
+		t.Errorf("EvalChecked on ill-typed input = %v, want Undefined", v)
+	}
+}
@@ -0,0 +1,342 @@
+// Package ir implements a small SSA-style intermediate representation
+// for the expression language defined in package expr, plus an
+// optimizer that runs constant folding, short-circuit simplification
+// and common-subexpression elimination over it.
+package ir
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Zommer2108/SWP-Bonus-Go/expr"
+)
+
+/////////////////////////////////////////////////////////////////////////
+// expr.Exp is a tree, so "building" the IR is really just linearizing
+// that tree into a flat list of three-address instructions, each
+// producing a single SSA value that later instructions refer to by ID.
+
+// irValue is the ID of an SSA value, i.e. the index of the instruction
+// that produces it.
+type irValue int
+
+type irOp int
+
+const (
+	irConst irOp = iota
+	irPlus
+	irMult
+	irAnd
+	irOr
+)
+
+func (op irOp) String() string {
+	switch op {
+	case irConst:
+		return "const"
+	case irPlus:
+		return "add"
+	case irMult:
+		return "mul"
+	case irAnd:
+		return "and"
+	case irOr:
+		return "or"
+	default:
+		return "?"
+	}
+}
+
+// IRInst is one three-address instruction. Const instructions carry their
+// value in Const; binary instructions read Args instead.
+type IRInst struct {
+	ID    irValue
+	Op    irOp
+	Args  [2]irValue
+	Const expr.Val
+}
+
+// IRProgram is the linearized form of an expr.Exp: its instructions in
+// definition order, plus the value that the whole program evaluates to.
+type IRProgram struct {
+	Insts  []IRInst
+	Result irValue
+}
+
+// String renders the program as one "%tN = op ..." line per instruction,
+// in the style of go/ssa's textual dumps.
+func (p *IRProgram) String() string {
+	var b strings.Builder
+	for _, inst := range p.Insts {
+		fmt.Fprintf(&b, "%%t%d = ", inst.ID)
+		if inst.Op == irConst {
+			fmt.Fprintf(&b, "const %s\n", expr.ShowVal(inst.Const))
+		} else {
+			fmt.Fprintf(&b, "%s %%t%d %%t%d\n", inst.Op, inst.Args[0], inst.Args[1])
+		}
+	}
+	fmt.Fprintf(&b, "return %%t%d\n", p.Result)
+	return b.String()
+}
+
+// Eval interprets the program and returns the value of its result,
+// applying the same type-mismatch-yields-Undefined rules as expr.Exp.Eval.
+func (p *IRProgram) Eval() expr.Val {
+	vals := make([]expr.Val, len(p.Insts))
+	for _, inst := range p.Insts {
+		switch inst.Op {
+		case irConst:
+			vals[inst.ID] = inst.Const
+		case irPlus:
+			vals[inst.ID] = evalArith(vals[inst.Args[0]], vals[inst.Args[1]], func(a, b int) int { return a + b })
+		case irMult:
+			vals[inst.ID] = evalArith(vals[inst.Args[0]], vals[inst.Args[1]], func(a, b int) int { return a * b })
+		case irAnd:
+			vals[inst.ID] = evalAnd(vals[inst.Args[0]], vals[inst.Args[1]])
+		case irOr:
+			vals[inst.ID] = evalOr(vals[inst.Args[0]], vals[inst.Args[1]])
+		}
+	}
+	return vals[p.Result]
+}
+
+func evalArith(l, r expr.Val, op func(a, b int) int) expr.Val {
+	if l.Flag == expr.ValueInt && r.Flag == expr.ValueInt {
+		return expr.MkInt(op(l.ValI, r.ValI))
+	}
+	return expr.MkUndefined()
+}
+
+func evalAnd(l, r expr.Val) expr.Val {
+	switch {
+	case l.Flag == expr.ValueBool && l.ValB == false:
+		return expr.MkBool(false)
+	case l.Flag == expr.ValueBool && r.Flag == expr.ValueBool:
+		return expr.MkBool(l.ValB && r.ValB)
+	}
+	return expr.MkUndefined()
+}
+
+func evalOr(l, r expr.Val) expr.Val {
+	switch {
+	case l.Flag == expr.ValueBool && l.ValB == true:
+		return expr.MkBool(true)
+	case l.Flag == expr.ValueBool && r.Flag == expr.ValueBool:
+		return expr.MkBool(l.ValB || r.ValB)
+	}
+	return expr.MkUndefined()
+}
+
+// irCSEKey identifies an instruction for value numbering: two
+// instructions with the same key compute the same value and can share
+// one SSA value.
+type irCSEKey struct {
+	op       irOp
+	a, b     irValue
+	isConst  bool
+	constVal expr.Val
+}
+
+type irBuilder struct {
+	prog  *IRProgram
+	table map[irCSEKey]irValue
+}
+
+func newIRBuilder() *irBuilder {
+	return &irBuilder{prog: &IRProgram{}, table: make(map[irCSEKey]irValue)}
+}
+
+func (b *irBuilder) emitConst(v expr.Val) irValue {
+	return b.emit(irCSEKey{op: irConst, isConst: true, constVal: v}, IRInst{Op: irConst, Const: v})
+}
+
+func (b *irBuilder) emitBinary(op irOp, a, c irValue) irValue {
+	return b.emit(irCSEKey{op: op, a: a, b: c}, IRInst{Op: op, Args: [2]irValue{a, c}})
+}
+
+func (b *irBuilder) emit(key irCSEKey, inst IRInst) irValue {
+	if id, ok := b.table[key]; ok {
+		return id
+	}
+	id := irValue(len(b.prog.Insts))
+	inst.ID = id
+	b.prog.Insts = append(b.prog.Insts, inst)
+	b.table[key] = id
+	return id
+}
+
+// Build lowers e into an IRProgram, sharing identical sub-expressions via
+// value numbering as it goes.
+func Build(e expr.Exp) *IRProgram {
+	b := newIRBuilder()
+	b.prog.Result = b.build(e, nil)
+	return b.prog
+}
+
+// irEnv maps a Let-bound name to the SSA value its Bound expression built
+// to. A Let needs no instruction of its own: it is built by binding the
+// name to that value and building Body under the extended irEnv, so a Var
+// reference compiles to nothing more than reusing an existing value.
+type irEnv map[string]irValue
+
+func (env irEnv) extend(name string, v irValue) irEnv {
+	next := make(irEnv, len(env)+1)
+	for k, id := range env {
+		next[k] = id
+	}
+	next[name] = v
+	return next
+}
+
+func (b *irBuilder) build(e expr.Exp, env irEnv) irValue {
+	switch x := e.(type) {
+	case expr.Num:
+		return b.emitConst(expr.MkInt(int(x)))
+	case expr.Bool:
+		return b.emitConst(expr.MkBool(bool(x)))
+	case expr.Var:
+		if id, ok := env[x.Name]; ok {
+			return id
+		}
+		return b.emitConst(expr.MkUndefined())
+	case expr.Let:
+		bound := b.build(x.Bound, env)
+		return b.build(x.Body, env.extend(x.Name, bound))
+	case expr.Plus:
+		return b.emitBinary(irPlus, b.build(x[0], env), b.build(x[1], env))
+	case expr.Mult:
+		return b.emitBinary(irMult, b.build(x[0], env), b.build(x[1], env))
+	case expr.And:
+		return b.emitBinary(irAnd, b.build(x[0], env), b.build(x[1], env))
+	case expr.Or:
+		return b.emitBinary(irOr, b.build(x[0], env), b.build(x[1], env))
+	default:
+		panic(fmt.Sprintf("ir: unhandled Exp %T", e))
+	}
+}
+
+// Optimize rewrites p in place, applying (in one pass, to a fixed point
+// of depth one):
+//
+//  1. constant folding of Plus/Mult over int constants and And/Or over
+//     bool constants;
+//  2. short-circuit simplification (false&&x -> false, true||x -> true),
+//     dropping the other operand even when it is ill-typed, matching
+//     Exp.Eval's semantics;
+//  3. common-subexpression elimination via value numbering.
+//
+// Unreachable instructions (dead code left behind by folding or
+// short-circuiting) are dropped from the result.
+func (p *IRProgram) Optimize() {
+	nb := newIRBuilder()
+	remap := make([]irValue, len(p.Insts))
+
+	for _, inst := range p.Insts {
+		var newID irValue
+		switch inst.Op {
+		case irConst:
+			newID = nb.emitConst(inst.Const)
+
+		case irPlus, irMult:
+			a, c := remap[inst.Args[0]], remap[inst.Args[1]]
+			ai, aok := nb.constInt(a)
+			ci, cok := nb.constInt(c)
+			if aok && cok {
+				if inst.Op == irPlus {
+					newID = nb.emitConst(expr.MkInt(ai + ci))
+				} else {
+					newID = nb.emitConst(expr.MkInt(ai * ci))
+				}
+			} else {
+				newID = nb.emitBinary(inst.Op, a, c)
+			}
+
+		case irAnd:
+			a, c := remap[inst.Args[0]], remap[inst.Args[1]]
+			ab, aok := nb.constBool(a)
+			cb, cok := nb.constBool(c)
+			switch {
+			case aok && !ab:
+				newID = a // false && x -> false, x dropped
+			case aok && cok:
+				newID = nb.emitConst(expr.MkBool(ab && cb))
+			default:
+				newID = nb.emitBinary(irAnd, a, c)
+			}
+
+		case irOr:
+			a, c := remap[inst.Args[0]], remap[inst.Args[1]]
+			ab, aok := nb.constBool(a)
+			cb, cok := nb.constBool(c)
+			switch {
+			case aok && ab:
+				newID = a // true || x -> true, x dropped
+			case aok && cok:
+				newID = nb.emitConst(expr.MkBool(ab || cb))
+			default:
+				newID = nb.emitBinary(irOr, a, c)
+			}
+		}
+		remap[inst.ID] = newID
+	}
+
+	nb.prog.Result = remap[p.Result]
+	nb.prog.compact()
+
+	*p = *nb.prog
+}
+
+func (b *irBuilder) constInt(v irValue) (int, bool) {
+	inst := b.prog.Insts[v]
+	if inst.Op == irConst && inst.Const.Flag == expr.ValueInt {
+		return inst.Const.ValI, true
+	}
+	return 0, false
+}
+
+func (b *irBuilder) constBool(v irValue) (bool, bool) {
+	inst := b.prog.Insts[v]
+	if inst.Op == irConst && inst.Const.Flag == expr.ValueBool {
+		return inst.Const.ValB, true
+	}
+	return false, false
+}
+
+// compact drops instructions unreachable from Result and renumbers the
+// rest contiguously from 0, which is what actually removes the dead code
+// that folding and short-circuiting leave behind.
+func (p *IRProgram) compact() {
+	reachable := make(map[irValue]bool)
+	var mark func(irValue)
+	mark = func(v irValue) {
+		if reachable[v] {
+			return
+		}
+		reachable[v] = true
+		inst := p.Insts[v]
+		if inst.Op != irConst {
+			mark(inst.Args[0])
+			mark(inst.Args[1])
+		}
+	}
+	mark(p.Result)
+
+	remap := make([]irValue, len(p.Insts))
+	var kept []IRInst
+	for _, inst := range p.Insts {
+		if !reachable[inst.ID] {
+			continue
+		}
+		newID := irValue(len(kept))
+		remap[inst.ID] = newID
+		if inst.Op != irConst {
+			inst.Args[0] = remap[inst.Args[0]]
+			inst.Args[1] = remap[inst.Args[1]]
+		}
+		inst.ID = newID
+		kept = append(kept, inst)
+	}
+
+	p.Insts = kept
+	p.Result = remap[p.Result]
+}
@@ -0,0 +1,83 @@
+package ir
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Zommer2108/SWP-Bonus-Go/expr"
+)
+
+func TestIRBuildAndEvalMatchesExpEval(t *testing.T) {
+	tests := []expr.Exp{
+		expr.MkPlus(expr.MkMult(expr.Num(1), expr.Num(2)), expr.Num(0)),
+		expr.MkAnd(expr.Bool(true), expr.Num(0)),
+		expr.MkOr(expr.Bool(false), expr.Num(0)),
+	}
+	for _, e := range tests {
+		got := Build(e).Eval()
+		want := e.Eval()
+		if got != want {
+			t.Errorf("Build(%s).Eval() = %v, want %v", e.Pretty(), got, want)
+		}
+	}
+}
+
+func TestIRConstantFolding(t *testing.T) {
+	e := expr.MkPlus(expr.MkMult(expr.Num(1), expr.Num(2)), expr.Num(3)) // (1*2)+3
+	p := Build(e)
+	p.Optimize()
+
+	if len(p.Insts) != 1 {
+		t.Fatalf("expected constant folding to leave a single instruction, got:\n%s", p)
+	}
+	if v := p.Eval(); v != expr.MkInt(5) {
+		t.Errorf("optimized program evaluates to %v, want 5", v)
+	}
+}
+
+func TestIRShortCircuitDropsIllTypedOperand(t *testing.T) {
+	e := expr.MkAnd(expr.Bool(false), expr.Num(0)) // false && 0, 0 is ill-typed but must be dropped
+	p := Build(e)
+	p.Optimize()
+
+	if len(p.Insts) != 1 {
+		t.Fatalf("expected short-circuit to drop the dead operand, got:\n%s", p)
+	}
+	if v := p.Eval(); v != expr.MkBool(false) {
+		t.Errorf("optimized program evaluates to %v, want false", v)
+	}
+}
+
+func TestIRCommonSubexpressionElimination(t *testing.T) {
+	shared := expr.MkPlus(expr.Num(1), expr.Num(2))
+	e := expr.MkMult(shared, shared)
+	p := Build(e)
+
+	// 1, 2, (1+2), (1+2)*(1+2): the two identical `1+2` sub-expressions
+	// must be value-numbered to the same instruction.
+	if len(p.Insts) != 4 {
+		t.Errorf("expected CSE to produce 4 instructions, got %d:\n%s", len(p.Insts), p)
+	}
+}
+
+func TestIRProgramString(t *testing.T) {
+	p := Build(expr.MkPlus(expr.Num(1), expr.Num(2)))
+	s := p.String()
+	if !strings.Contains(s, "const") || !strings.Contains(s, "add") {
+		t.Errorf("String() = %q, expected const and add instructions", s)
+	}
+}
+
+func TestIRLetFoldsThroughVariables(t *testing.T) {
+	// let x = 1 + 2 in x * x
+	e := expr.MkLet("x", expr.MkPlus(expr.Num(1), expr.Num(2)), expr.MkMult(expr.MkVar("x"), expr.MkVar("x")))
+	p := Build(e)
+	p.Optimize()
+
+	if len(p.Insts) != 1 {
+		t.Fatalf("expected the whole let to constant-fold to a single instruction, got:\n%s", p)
+	}
+	if v := p.Eval(); v != expr.MkInt(9) {
+		t.Errorf("optimized program evaluates to %v, want 9", v)
+	}
+}
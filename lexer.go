@@ -0,0 +1,187 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+/////////////////////////////////////////////////////////////////////////
+// Lexer for the expression language.
+//
+// Turns source text into a flat stream of tokens. Modelled loosely on the
+// style of Go's own hand-written scanners: a single forward-only cursor,
+// no backtracking, positions tracked as we go so the parser can attach
+// line/column information to diagnostics.
+
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokIllegal
+	tokNumber
+	tokTrue
+	tokFalse
+	tokPlus
+	tokStar
+	tokAnd // &&
+	tokOr  // ||
+	tokLParen
+	tokRParen
+)
+
+// position is a 1-based line/column pair.
+type position struct {
+	line int
+	col  int
+}
+
+func (p position) String() string {
+	return fmt.Sprintf("%d:%d", p.line, p.col)
+}
+
+type token struct {
+	kind tokenKind
+	lit  string
+	pos  position
+}
+
+func (t token) String() string {
+	if t.kind == tokEOF {
+		return "end of input"
+	}
+	if t.lit != "" {
+		return fmt.Sprintf("%q", t.lit)
+	}
+	return tokenKindNames[t.kind]
+}
+
+var tokenKindNames = map[tokenKind]string{
+	tokEOF:     "EOF",
+	tokIllegal: "illegal token",
+	tokNumber:  "number",
+	tokTrue:    "true",
+	tokFalse:   "false",
+	tokPlus:    "+",
+	tokStar:    "*",
+	tokAnd:     "&&",
+	tokOr:      "||",
+	tokLParen:  "(",
+	tokRParen:  ")",
+}
+
+type lexer struct {
+	src  string
+	pos  int // byte offset of the next rune to read
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, pos: 0, line: 1, col: 1}
+}
+
+func (l *lexer) peekByte() byte {
+	if l.pos >= len(l.src) {
+		return 0
+	}
+	return l.src[l.pos]
+}
+
+func (l *lexer) advance() byte {
+	b := l.src[l.pos]
+	l.pos++
+	if b == '\n' {
+		l.line++
+		l.col = 1
+	} else {
+		l.col++
+	}
+	return b
+}
+
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.src) && strings.IndexByte(" \t\r\n", l.peekByte()) >= 0 {
+		l.advance()
+	}
+}
+
+// next scans and returns the next token in the input.
+func (l *lexer) next() token {
+	l.skipSpace()
+
+	start := position{line: l.line, col: l.col}
+
+	if l.pos >= len(l.src) {
+		return token{kind: tokEOF, pos: start}
+	}
+
+	c := l.peekByte()
+
+	switch {
+	case c >= '0' && c <= '9':
+		return l.lexNumber(start)
+	case isIdentStart(c):
+		return l.lexIdent(start)
+	case c == '+':
+		l.advance()
+		return token{kind: tokPlus, lit: "+", pos: start}
+	case c == '*':
+		l.advance()
+		return token{kind: tokStar, lit: "*", pos: start}
+	case c == '(':
+		l.advance()
+		return token{kind: tokLParen, lit: "(", pos: start}
+	case c == ')':
+		l.advance()
+		return token{kind: tokRParen, lit: ")", pos: start}
+	case c == '&':
+		l.advance()
+		if l.peekByte() == '&' {
+			l.advance()
+			return token{kind: tokAnd, lit: "&&", pos: start}
+		}
+		return token{kind: tokIllegal, lit: "&", pos: start}
+	case c == '|':
+		l.advance()
+		if l.peekByte() == '|' {
+			l.advance()
+			return token{kind: tokOr, lit: "||", pos: start}
+		}
+		return token{kind: tokIllegal, lit: "|", pos: start}
+	default:
+		l.advance()
+		return token{kind: tokIllegal, lit: string(c), pos: start}
+	}
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentCont(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+func (l *lexer) lexNumber(start position) token {
+	begin := l.pos
+	for l.pos < len(l.src) && l.peekByte() >= '0' && l.peekByte() <= '9' {
+		l.advance()
+	}
+	return token{kind: tokNumber, lit: l.src[begin:l.pos], pos: start}
+}
+
+func (l *lexer) lexIdent(start position) token {
+	begin := l.pos
+	for l.pos < len(l.src) && isIdentCont(l.peekByte()) {
+		l.advance()
+	}
+	lit := l.src[begin:l.pos]
+	switch lit {
+	case "true":
+		return token{kind: tokTrue, lit: lit, pos: start}
+	case "false":
+		return token{kind: tokFalse, lit: lit, pos: start}
+	default:
+		return token{kind: tokIllegal, lit: lit, pos: start}
+	}
+}
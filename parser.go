@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/Zommer2108/SWP-Bonus-Go/expr"
+)
+
+/////////////////////////////////////////////////////////////////////////
+// Recursive-descent parser for the expression language.
+//
+// Grammar (lowest to highest precedence):
+//
+//	expr   = orExpr
+//	orExpr = andExpr ("||" andExpr)*
+//	andExpr = addExpr ("&&" addExpr)*
+//	addExpr = mulExpr ("+" mulExpr)*
+//	mulExpr = primary ("*" primary)*
+//	primary = NUMBER | "true" | "false" | "(" expr ")"
+//
+// This matches the fully-parenthesized output of Exp.Pretty(), so
+// Parse(e.Pretty()) round-trips for every e built from non-negative
+// Num, Bool, MkPlus, MkMult, MkAnd and MkOr. The grammar has no minus
+// sign, variables or let, so the Pretty() form of a negative Num, a Var
+// or a Let does not parse.
+
+// ParseError reports a single syntactic problem, carrying the offending
+// token and its source position so callers can point users at the exact
+// spot that went wrong.
+type ParseError struct {
+	Pos position
+	Tok string
+	Msg string
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("%s: %s (got %s)", e.Pos, e.Msg, e.Tok)
+}
+
+// ParseErrors collects every error found during a single Parse call.
+type ParseErrors []ParseError
+
+func (es ParseErrors) Error() string {
+	s := ""
+	for i, e := range es {
+		if i > 0 {
+			s += "\n"
+		}
+		s += e.Error()
+	}
+	return s
+}
+
+type parser struct {
+	lex  *lexer
+	tok  token
+	errs ParseErrors
+}
+
+// Parse parses src as a single expr.Exp. Parsing does not stop at the first
+// error: the parser resynchronizes at the next ")" or end-of-input and
+// keeps going, so the returned error (a ParseErrors) can report several
+// mistakes from one pass. err is nil iff src is syntactically valid.
+func Parse(src string) (expr.Exp, error) {
+	p := &parser{lex: newLexer(src)}
+	p.advance()
+
+	e := p.parseExpr()
+
+	if p.tok.kind != tokEOF {
+		p.errorf("unexpected trailing input")
+	}
+
+	if len(p.errs) == 0 {
+		return e, nil
+	}
+	return e, p.errs
+}
+
+func (p *parser) advance() {
+	p.tok = p.lex.next()
+}
+
+func (p *parser) errorf(format string, args ...interface{}) {
+	p.errs = append(p.errs, ParseError{
+		Pos: p.tok.pos,
+		Tok: p.tok.String(),
+		Msg: fmt.Sprintf(format, args...),
+	})
+}
+
+// sync recovers from a parse error by skipping tokens until it finds a
+// ")" (which it leaves unconsumed, so the caller can still match it) or
+// end-of-input.
+func (p *parser) sync() {
+	for p.tok.kind != tokRParen && p.tok.kind != tokEOF {
+		p.advance()
+	}
+}
+
+func (p *parser) parseExpr() expr.Exp {
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() expr.Exp {
+	left := p.parseAnd()
+	for p.tok.kind == tokOr {
+		p.advance()
+		right := p.parseAnd()
+		left = expr.MkOr(left, right)
+	}
+	return left
+}
+
+func (p *parser) parseAnd() expr.Exp {
+	left := p.parseAdd()
+	for p.tok.kind == tokAnd {
+		p.advance()
+		right := p.parseAdd()
+		left = expr.MkAnd(left, right)
+	}
+	return left
+}
+
+func (p *parser) parseAdd() expr.Exp {
+	left := p.parseMul()
+	for p.tok.kind == tokPlus {
+		p.advance()
+		right := p.parseMul()
+		left = expr.MkPlus(left, right)
+	}
+	return left
+}
+
+func (p *parser) parseMul() expr.Exp {
+	left := p.parsePrimary()
+	for p.tok.kind == tokStar {
+		p.advance()
+		right := p.parsePrimary()
+		left = expr.MkMult(left, right)
+	}
+	return left
+}
+
+func (p *parser) parsePrimary() expr.Exp {
+	switch p.tok.kind {
+	case tokNumber:
+		n := 0
+		for _, c := range p.tok.lit {
+			n = n*10 + int(c-'0')
+		}
+		p.advance()
+		return expr.Num(n)
+
+	case tokTrue:
+		p.advance()
+		return expr.Bool(true)
+
+	case tokFalse:
+		p.advance()
+		return expr.Bool(false)
+
+	case tokLParen:
+		p.advance()
+		e := p.parseExpr()
+		if p.tok.kind == tokRParen {
+			p.advance()
+		} else {
+			p.errorf("expected ')'")
+		}
+		return e
+
+	default:
+		p.errorf("expected an expression")
+		p.sync()
+		return expr.Num(0)
+	}
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Zommer2108/SWP-Bonus-Go/expr"
+)
+
+func mustParse(t *testing.T, src string) expr.Exp {
+	t.Helper()
+	e, err := Parse(src)
+	if err != nil {
+		t.Fatalf("Parse(%q): unexpected error: %v", src, err)
+	}
+	return e
+}
+
+func TestParsePrecedence(t *testing.T) {
+	e := mustParse(t, "1+2*3")
+	want := "(1+(2*3))"
+	if got := e.Pretty(); got != want {
+		t.Errorf("Parse(%q).Pretty() = %q, want %q", "1+2*3", got, want)
+	}
+}
+
+func TestParseAssociativity(t *testing.T) {
+	e := mustParse(t, "1+2+3")
+	want := "((1+2)+3)"
+	if got := e.Pretty(); got != want {
+		t.Errorf("Parse(%q).Pretty() = %q, want %q", "1+2+3", got, want)
+	}
+}
+
+func TestParseOrAndPrecedence(t *testing.T) {
+	e := mustParse(t, "true||false&&true")
+	want := "(true||(false&&true))"
+	if got := e.Pretty(); got != want {
+		t.Errorf("Parse(%q).Pretty() = %q, want %q", "true||false&&true", got, want)
+	}
+}
+
+func TestParseParentheses(t *testing.T) {
+	e := mustParse(t, "(1+2)*3")
+	want := "((1+2)*3)"
+	if got := e.Pretty(); got != want {
+		t.Errorf("Parse(%q).Pretty() = %q, want %q", "(1+2)*3", got, want)
+	}
+}
+
+func TestParseMalformedInput(t *testing.T) {
+	tests := []string{
+		"1+",
+		"(1+2",
+		"*3",
+		"1 2",
+	}
+	for _, src := range tests {
+		if _, err := Parse(src); err == nil {
+			t.Errorf("Parse(%q): expected error, got none", src)
+		}
+	}
+}
+
+func TestParseReportsMultipleErrors(t *testing.T) {
+	_, err := Parse("(*)+(*)")
+	errs, ok := err.(ParseErrors)
+	if !ok {
+		t.Fatalf("expected ParseErrors, got %T (%v)", err, err)
+	}
+	if len(errs) < 2 {
+		t.Errorf("expected at least 2 errors from two malformed groups, got %d: %v", len(errs), errs)
+	}
+}
+
+func TestParseRoundTripsExample13(t *testing.T) {
+	asts := []expr.Exp{
+		expr.MkPlus(expr.MkMult(expr.Num(1), expr.Num(2)), expr.Num(0)),
+		expr.MkAnd(expr.Bool(true), expr.Num(0)),
+		expr.MkOr(expr.Bool(false), expr.Num(0)),
+	}
+
+	for _, ast := range asts {
+		src := ast.Pretty()
+		got := mustParse(t, src)
+		if got.Pretty() != src {
+			t.Errorf("Parse(%q).Pretty() = %q, want %q", src, got.Pretty(), src)
+		}
+	}
+}